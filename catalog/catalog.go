@@ -0,0 +1,97 @@
+// Package catalog lets services register error codes once, up front, and
+// construct errors from a code thereafter instead of repeating
+// HTTPStatus/Key/Detail at every call site.
+package catalog
+
+import (
+	"fmt"
+	"sync"
+
+	apierror "github.com/next-trace/scg-error/error"
+)
+
+// Spec describes how to construct an *error.Error for a registered code:
+// its default Key/HTTPStatus, a Detail template consumed via fmt.Sprintf,
+// and an optional GRPCCode override for transport adapters that map
+// HTTPStatus to a gRPC status code by convention.
+type Spec struct {
+	// Code is set by Register to match the registration key; any value
+	// supplied here is overwritten.
+	Code string
+
+	Key        string
+	HTTPStatus int
+	Detail     string
+
+	// GRPCCode overrides the HTTPStatus-derived gRPC code when set. nil
+	// means "derive from HTTPStatus using the transport's own convention".
+	GRPCCode *int
+}
+
+// registry holds the process-wide code -> Spec table.
+var registry sync.Map
+
+// Register adds spec to the catalog under code. It panics if code is
+// already registered: a duplicate registration is a programming error
+// (two packages picked the same code) and should fail at init rather than
+// silently shadow the first definition.
+func Register(code string, spec Spec) {
+	spec.Code = code
+
+	if _, loaded := registry.LoadOrStore(code, spec); loaded {
+		panic(fmt.Sprintf("catalog: code %q already registered", code))
+	}
+}
+
+// Lookup returns the Spec registered under code, and false if code isn't
+// registered. Use this when an unregistered code is an expected, recoverable
+// condition (e.g. a transport adapter consulting the catalog opportunistically);
+// use Must when it's a programming error.
+func Lookup(code string) (Spec, bool) {
+	v, ok := registry.Load(code)
+	if !ok {
+		return Spec{}, false
+	}
+
+	return v.(Spec), true
+}
+
+// Must looks up the Spec registered under code, panicking if it isn't
+// found so a typo'd code surfaces immediately instead of constructing a
+// zero-value error at runtime.
+func Must(code string) Spec {
+	spec, ok := Lookup(code)
+	if !ok {
+		panic(fmt.Sprintf("catalog: code %q is not registered", code))
+	}
+
+	return spec
+}
+
+// New constructs a fully-populated *error.Error from the Spec registered
+// under code. When args is non-empty, Detail is formatted via
+// fmt.Sprintf(spec.Detail, args...); otherwise spec.Detail is used as-is.
+// Like Must, New panics on an unregistered code.
+func New(code string, args ...any) *apierror.Error {
+	spec := Must(code)
+
+	detail := spec.Detail
+	if len(args) > 0 {
+		detail = fmt.Sprintf(spec.Detail, args...)
+	}
+
+	return apierror.New(spec.HTTPStatus, code, spec.Key, detail, nil)
+}
+
+// Export returns every registered Spec, e.g. for generating docs or an
+// OpenAPI error table. Order is unspecified.
+func Export() []Spec {
+	specs := make([]Spec, 0)
+
+	registry.Range(func(_, v any) bool {
+		specs = append(specs, v.(Spec))
+		return true
+	})
+
+	return specs
+}
@@ -0,0 +1,148 @@
+package catalog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/next-trace/scg-error/catalog"
+)
+
+func TestRegisterAndNew(t *testing.T) {
+	t.Parallel()
+
+	catalog.Register("catalog_test.customer_not_found", catalog.Spec{
+		Key:        "not_found",
+		HTTPStatus: 404,
+		Detail:     "customer %s not found",
+	})
+
+	e := catalog.New("catalog_test.customer_not_found", "42")
+
+	if e.HTTPStatus() != 404 || e.Key() != "not_found" || e.Code() != "catalog_test.customer_not_found" {
+		t.Fatalf("unexpected error fields: %+v", e)
+	}
+
+	if e.Detail() != "customer 42 not found" {
+		t.Fatalf("Detail=%q", e.Detail())
+	}
+}
+
+func TestNew_NoArgsUsesDetailVerbatim(t *testing.T) {
+	t.Parallel()
+
+	catalog.Register("catalog_test.plain", catalog.Spec{
+		Key:        "internal",
+		HTTPStatus: 500,
+		Detail:     "something went wrong",
+	})
+
+	e := catalog.New("catalog_test.plain")
+	if e.Detail() != "something went wrong" {
+		t.Fatalf("Detail=%q", e.Detail())
+	}
+}
+
+func TestRegister_DuplicatePanics(t *testing.T) {
+	t.Parallel()
+
+	catalog.Register("catalog_test.dup", catalog.Spec{Key: "internal", HTTPStatus: 500, Detail: "x"})
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic on duplicate registration")
+		}
+	}()
+
+	catalog.Register("catalog_test.dup", catalog.Spec{Key: "internal", HTTPStatus: 500, Detail: "y"})
+}
+
+func TestLookup_Found(t *testing.T) {
+	t.Parallel()
+
+	catalog.Register("catalog_test.lookup_found", catalog.Spec{Key: "not_found", HTTPStatus: 404, Detail: "x"})
+
+	spec, ok := catalog.Lookup("catalog_test.lookup_found")
+	if !ok {
+		t.Fatalf("expected Lookup to find the registered spec")
+	}
+
+	if spec.Code != "catalog_test.lookup_found" || spec.HTTPStatus != 404 {
+		t.Fatalf("unexpected spec: %+v", spec)
+	}
+}
+
+func TestLookup_MissingReturnsFalse(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := catalog.Lookup("catalog_test.does_not_exist"); ok {
+		t.Fatalf("expected Lookup to report false for an unregistered code")
+	}
+}
+
+func TestMust_MissingPanics(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic for unregistered code")
+		}
+	}()
+
+	catalog.Must("catalog_test.does_not_exist")
+}
+
+func TestExport_ContainsRegistered(t *testing.T) {
+	t.Parallel()
+
+	catalog.Register("catalog_test.export_me", catalog.Spec{Key: "validation", HTTPStatus: 400, Detail: "bad"})
+
+	for _, spec := range catalog.Export() {
+		if spec.Code == "catalog_test.export_me" {
+			if spec.Key != "validation" || spec.HTTPStatus != 400 {
+				t.Fatalf("unexpected exported spec: %+v", spec)
+			}
+
+			return
+		}
+	}
+
+	t.Fatalf("expected Export() to contain the registered spec")
+}
+
+func TestValidate_FlagsMismatch(t *testing.T) {
+	t.Parallel()
+
+	catalog.Register("catalog_test.mismatched", catalog.Spec{Key: "not_found", HTTPStatus: 500, Detail: "x"})
+
+	var found bool
+
+	for _, err := range catalog.Validate() {
+		if strings.Contains(err.Error(), "catalog_test.mismatched") {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Fatalf("expected Validate() to flag the not_found/500 mismatch")
+	}
+}
+
+func TestLoadFromYAML(t *testing.T) {
+	t.Parallel()
+
+	src := `
+- code: catalog_test.yaml_loaded
+  key: conflict
+  http_status: 409
+  detail: "conflict on %s"
+`
+
+	if err := catalog.LoadFromYAML(strings.NewReader(src)); err != nil {
+		t.Fatalf("LoadFromYAML error: %v", err)
+	}
+
+	e := catalog.New("catalog_test.yaml_loaded", "resource-1")
+	if e.HTTPStatus() != 409 || e.Detail() != "conflict on resource-1" {
+		t.Fatalf("unexpected error from yaml-loaded spec: %+v", e)
+	}
+}
@@ -0,0 +1,36 @@
+package catalog
+
+import "fmt"
+
+// categoryExpectedStatus maps a well-known Key to the HTTPStatus values
+// services are expected to pair it with. Keys outside this table aren't
+// checked by Validate.
+var categoryExpectedStatus = map[string]func(status int) bool{
+	"not_found":    func(s int) bool { return s == 404 },
+	"validation":   func(s int) bool { return s == 400 || s == 422 },
+	"unauthorized": func(s int) bool { return s == 401 },
+	"forbidden":    func(s int) bool { return s == 403 },
+	"conflict":     func(s int) bool { return s == 409 },
+	"internal":     func(s int) bool { return s >= 500 && s < 600 },
+}
+
+// Validate flags registered codes whose HTTPStatus disagrees with their Key
+// category, e.g. a "not_found" Key paired with a 500 HTTPStatus. Run this
+// in an init-time self-check or a CI step, not on a hot path.
+func Validate() []error {
+	var problems []error
+
+	for _, spec := range Export() {
+		check, known := categoryExpectedStatus[spec.Key]
+		if !known || check(spec.HTTPStatus) {
+			continue
+		}
+
+		problems = append(problems, fmt.Errorf(
+			"catalog: code %q has key %q but HTTPStatus=%d disagrees with it",
+			spec.Code, spec.Key, spec.HTTPStatus,
+		))
+	}
+
+	return problems
+}
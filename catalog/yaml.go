@@ -0,0 +1,44 @@
+package catalog
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlEntry mirrors the declarative shape operators author by hand, e.g.:
+//
+//   - code: customer.not_found
+//     key: not_found
+//     http_status: 404
+//     detail: "customer %s not found"
+type yamlEntry struct {
+	Code       string `yaml:"code"`
+	Key        string `yaml:"key"`
+	HTTPStatus int    `yaml:"http_status"`
+	Detail     string `yaml:"detail"`
+	GRPCCode   *int   `yaml:"grpc_code"`
+}
+
+// LoadFromYAML registers every entry decoded from r, so operators can
+// define the whole error table declaratively instead of one Register call
+// per code. Duplicate codes (within r or against an already-registered
+// code) panic via Register.
+func LoadFromYAML(r io.Reader) error {
+	var entries []yamlEntry
+	if err := yaml.NewDecoder(r).Decode(&entries); err != nil {
+		return fmt.Errorf("catalog: decode yaml: %w", err)
+	}
+
+	for _, e := range entries {
+		Register(e.Code, Spec{
+			Key:        e.Key,
+			HTTPStatus: e.HTTPStatus,
+			Detail:     e.Detail,
+			GRPCCode:   e.GRPCCode,
+		})
+	}
+
+	return nil
+}
@@ -13,6 +13,18 @@ package contract
 //
 // The interface intentionally contains only getters and Unwrap to keep
 // the API surface minimal and transport-agnostic.
+//
+// Identity via errors.Is: this interface does not require an Is(error) bool
+// method, so by default errors.Is falls back to pointer equality. An
+// implementation MAY define Is to treat two distinct values as equivalent
+// (e.g. by Code rather than pointer identity) when that implementation's
+// values can be legitimately reconstructed from a lossy encoding — decoding
+// a transport payload back into a value that was never the original pointer
+// is the motivating case. Doing so redefines error identity for every
+// errors.Is/errors.As caller against that type, so it must be a deliberate,
+// documented choice on the implementing type, not an incidental side effect
+// of an unrelated fix. See error.Error.Is for the reference implementation
+// and its rationale.
 type Error interface {
 	error
 	HTTPStatus() int
@@ -0,0 +1,17 @@
+package contract
+
+// Frame describes a single call-site captured in an error's stack trace.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// StackTracer is an optional interface implemented by errors that capture a
+// call-site stack trace. It is deliberately kept out of Error so that the
+// core contract stays minimal and stable; callers that need stack frames
+// should type-assert for this interface instead.
+type StackTracer interface {
+	// Stack returns the captured frames, or nil if none were captured.
+	Stack() []Frame
+}
@@ -0,0 +1,155 @@
+// Package problem converts contract.Error values to and from RFC 7807
+// "application/problem+json" documents, so HTTP transports can expose a
+// standard error body without depending on the concrete error package.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/next-trace/scg-error/contract"
+	apierror "github.com/next-trace/scg-error/error"
+)
+
+// ContentType is the media type written by Write and expected by Unmarshal.
+const ContentType = "application/problem+json"
+
+// Encoder renders contract.Error values as RFC 7807 documents.
+//
+// The zero value is ready to use: type falls back to "about:blank",
+// instance is omitted, and context is promoted as-is.
+type Encoder struct {
+	// BaseTypeURL prefixes Code to build the "type" member, e.g.
+	// "https://errors.example.com" + "/" + "customer.not_found". Left empty,
+	// "type" is "about:blank" per RFC 7807.
+	BaseTypeURL string
+
+	// InstanceFromRequest derives the "instance" member from the originating
+	// request. Optional; only consulted when a request is available (see
+	// (*Encoder).WriteRequest).
+	InstanceFromRequest func(*http.Request) string
+
+	// Redact runs on the error's Context() before it is promoted into the
+	// document, so services can strip or mask fields that must never reach
+	// clients. Optional.
+	Redact func(map[string]any) map[string]any
+}
+
+// DefaultEncoder is used by the package-level Marshal and Write helpers.
+var DefaultEncoder = &Encoder{}
+
+// Marshal renders e as an RFC 7807 document using DefaultEncoder.
+func Marshal(e contract.Error) ([]byte, error) { return DefaultEncoder.Marshal(e) }
+
+// Write renders e as an RFC 7807 document and writes it to w using
+// DefaultEncoder, with no request-derived "instance" member. Use
+// WriteRequest on an *Encoder whose InstanceFromRequest is set to populate
+// "instance".
+func Write(w http.ResponseWriter, e contract.Error) error {
+	return DefaultEncoder.WriteRequest(w, nil, e)
+}
+
+// Marshal renders e as an RFC 7807 "application/problem+json" document.
+func (enc *Encoder) Marshal(e contract.Error) ([]byte, error) {
+	return json.Marshal(enc.document(e, nil))
+}
+
+// Write renders e and writes it to w, with no request-derived "instance".
+func (enc *Encoder) Write(w http.ResponseWriter, e contract.Error) error {
+	return enc.WriteRequest(w, nil, e)
+}
+
+// WriteRequest renders e and writes it to w, setting Content-Type and the
+// error's HTTPStatus, and populating "instance" from r via
+// InstanceFromRequest when both are set.
+func (enc *Encoder) WriteRequest(w http.ResponseWriter, r *http.Request, e contract.Error) error {
+	body, err := json.Marshal(enc.document(e, r))
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(e.HTTPStatus())
+	_, err = w.Write(body)
+
+	return err
+}
+
+// reservedFields are the RFC 7807 core members plus our own "code" member;
+// context entries with these keys are dropped rather than allowed to
+// clobber them.
+var reservedFields = map[string]bool{
+	"type":     true,
+	"title":    true,
+	"status":   true,
+	"detail":   true,
+	"instance": true,
+	"code":     true,
+}
+
+func (enc *Encoder) document(e contract.Error, r *http.Request) map[string]any {
+	doc := map[string]any{
+		"type":   enc.typeURL(e.Code()),
+		"title":  e.Key(),
+		"status": e.HTTPStatus(),
+		"detail": e.Detail(),
+		"code":   e.Code(),
+	}
+
+	if r != nil && enc.InstanceFromRequest != nil {
+		if instance := enc.InstanceFromRequest(r); instance != "" {
+			doc["instance"] = instance
+		}
+	}
+
+	ctx := e.Context()
+	if enc.Redact != nil {
+		ctx = enc.Redact(ctx)
+	}
+
+	for k, v := range ctx {
+		if reservedFields[k] {
+			continue
+		}
+
+		doc[k] = v
+	}
+
+	return doc
+}
+
+func (enc *Encoder) typeURL(code string) string {
+	if enc.BaseTypeURL == "" {
+		return "about:blank"
+	}
+
+	return strings.TrimRight(enc.BaseTypeURL, "/") + "/" + code
+}
+
+// Unmarshal parses an RFC 7807 document back into an *error.Error. Extension
+// members that aren't part of the RFC 7807 core (and aren't "code") become
+// the resulting error's Context.
+func Unmarshal(data []byte) (*apierror.Error, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	code, _ := doc["code"].(string)
+	title, _ := doc["title"].(string)
+	detail, _ := doc["detail"].(string)
+	status, _ := doc["status"].(float64)
+
+	ctx := make(map[string]any, len(doc))
+
+	for k, v := range doc {
+		if reservedFields[k] {
+			continue
+		}
+
+		ctx[k] = v
+	}
+
+	return apierror.New(int(status), code, title, detail, ctx), nil
+}
@@ -0,0 +1,220 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/next-trace/scg-error/encoding/problem"
+	apierror "github.com/next-trace/scg-error/error"
+)
+
+func TestMarshal_CoreFields(t *testing.T) {
+	t.Parallel()
+
+	e := apierror.New(http.StatusNotFound, "customer.not_found", "not_found", "customer 42 not found",
+		map[string]any{"customer_id": "42"})
+
+	enc := &problem.Encoder{BaseTypeURL: "https://errors.example.com"}
+
+	body, err := enc.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if doc["type"] != "https://errors.example.com/customer.not_found" {
+		t.Fatalf("type=%v", doc["type"])
+	}
+
+	if doc["title"] != "not_found" || doc["detail"] != "customer 42 not found" || doc["code"] != "customer.not_found" {
+		t.Fatalf("unexpected doc: %#v", doc)
+	}
+
+	if doc["status"].(float64) != http.StatusNotFound {
+		t.Fatalf("status=%v", doc["status"])
+	}
+
+	if doc["customer_id"] != "42" {
+		t.Fatalf("expected context to be promoted, got: %#v", doc)
+	}
+}
+
+func TestMarshal_DefaultTypeIsAboutBlank(t *testing.T) {
+	t.Parallel()
+
+	e := apierror.E("c", "k")
+
+	body, err := problem.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	var doc map[string]any
+	_ = json.Unmarshal(body, &doc)
+
+	if doc["type"] != "about:blank" {
+		t.Fatalf("type=%v want about:blank", doc["type"])
+	}
+}
+
+func TestMarshal_ReservedFieldGuardDropsClobber(t *testing.T) {
+	t.Parallel()
+
+	e := apierror.New(http.StatusBadRequest, "validation.failed", "validation", "payload invalid",
+		map[string]any{"status": "hijacked", "type": "hijacked", "safe": "ok"})
+
+	body, err := problem.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	var doc map[string]any
+	_ = json.Unmarshal(body, &doc)
+
+	if doc["status"].(float64) != http.StatusBadRequest {
+		t.Fatalf("reserved field 'status' was clobbered: %v", doc["status"])
+	}
+
+	if doc["type"] != "about:blank" {
+		t.Fatalf("reserved field 'type' was clobbered: %v", doc["type"])
+	}
+
+	if doc["safe"] != "ok" {
+		t.Fatalf("non-reserved context key dropped: %#v", doc)
+	}
+}
+
+func TestMarshal_Redact(t *testing.T) {
+	t.Parallel()
+
+	e := apierror.New(http.StatusInternalServerError, "internal.error", "internal", "internal error",
+		map[string]any{"secret": "do-not-leak", "op": "CustomerRepo.Get"})
+
+	enc := &problem.Encoder{
+		Redact: func(ctx map[string]any) map[string]any {
+			delete(ctx, "secret")
+			return ctx
+		},
+	}
+
+	body, err := enc.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	if contains(string(body), "do-not-leak") {
+		t.Fatalf("redacted field leaked: %s", body)
+	}
+
+	if !contains(string(body), "CustomerRepo.Get") {
+		t.Fatalf("non-redacted field missing: %s", body)
+	}
+}
+
+func TestWriteRequest_InstanceFromRequest(t *testing.T) {
+	t.Parallel()
+
+	enc := &problem.Encoder{
+		InstanceFromRequest: func(r *http.Request) string { return r.URL.Path },
+	}
+
+	e := apierror.New(http.StatusNotFound, "customer.not_found", "not_found", "not found", nil)
+	req := httptest.NewRequest(http.MethodGet, "/customers/42", nil)
+	rec := httptest.NewRecorder()
+
+	if err := enc.WriteRequest(rec, req, e); err != nil {
+		t.Fatalf("WriteRequest error: %v", err)
+	}
+
+	if got := rec.Header().Get("Content-Type"); got != problem.ContentType {
+		t.Fatalf("Content-Type=%q want=%q", got, problem.ContentType)
+	}
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status=%d want=%d", rec.Code, http.StatusNotFound)
+	}
+
+	var doc map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &doc)
+
+	if doc["instance"] != "/customers/42" {
+		t.Fatalf("instance=%v", doc["instance"])
+	}
+}
+
+func TestWrite_NoRequest_OmitsInstance(t *testing.T) {
+	t.Parallel()
+
+	e := apierror.New(http.StatusNotFound, "customer.not_found", "not_found", "not found", nil)
+	rec := httptest.NewRecorder()
+
+	if err := problem.Write(rec, e); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+
+	var doc map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &doc)
+
+	if _, ok := doc["instance"]; ok {
+		t.Fatalf("expected no instance without a request, got: %#v", doc)
+	}
+}
+
+func TestUnmarshal_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := apierror.New(http.StatusNotFound, "customer.not_found", "not_found", "customer 42 not found",
+		map[string]any{"customer_id": "42"})
+
+	body, err := problem.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	decoded, err := problem.Unmarshal(body)
+	if err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	if decoded.HTTPStatus() != original.HTTPStatus() ||
+		decoded.Code() != original.Code() ||
+		decoded.Key() != original.Key() ||
+		decoded.Detail() != original.Detail() {
+		t.Fatalf("round-trip mismatch: got=%+v", decoded)
+	}
+
+	if decoded.Context()["customer_id"] != "42" {
+		t.Fatalf("round-trip lost context: %#v", decoded.Context())
+	}
+
+	var out *apierror.Error
+	if !errors.As(decoded, &out) {
+		t.Fatalf("errors.As should succeed on decoded value")
+	}
+}
+
+func TestMarshal_NoRawCauseLeak(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("driver: bad connection")
+	e := apierror.Wrap(cause, http.StatusInternalServerError, "internal.error", "internal", "internal error", nil)
+
+	body, err := problem.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	if contains(string(body), "driver: bad connection") {
+		t.Fatalf("cause chain leaked into problem document: %s", body)
+	}
+}
+
+func contains(s, sub string) bool { return strings.Contains(s, sub) }
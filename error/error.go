@@ -18,6 +18,7 @@ import (
 //   - Key:        category/namespace (e.g. "not_found", "validation")
 //   - Detail:     client-safe human detail (no secrets)
 //   - Context:    everything else (validation issues, ids, hints, etc.)
+//   - stack:      optional, lazily-resolved call-site frames (see stack.go)
 type Error struct {
 	httpStatus int
 	code       string
@@ -25,6 +26,10 @@ type Error struct {
 	detail     string
 	context    map[string]any
 	cause      error
+
+	// stack holds raw PCs from runtime.Callers; resolved to Frame values on
+	// demand by Stack(). Left nil unless stack capture was requested.
+	stack []uintptr
 }
 
 // compile-time guarantee that *Error implements contract.Error
@@ -46,6 +51,28 @@ func (e *Error) Error() string {
 
 func (e *Error) Unwrap() error { return e.cause }
 
+// Is reports whether target is a non-nil *Error with the same Code as e.
+//
+// This is a deliberate, repo-wide redefinition of error identity for *Error:
+// errors.Is(a, b) is true whenever a and b share a Code, even if they were
+// constructed independently and are otherwise unrelated. Plain pointer
+// identity (the errors.Is default without an Is method) cannot survive
+// transport round-tripping, since a decoded *Error is never the same
+// pointer as the one that was encoded; see transport/grpcstatus's cause
+// round-trip, the motivating case for this method. Code is treated as a
+// stable, unique identifier for "this kind of error" throughout this
+// module, so matching on it is intended to extend to every *Error
+// consumer, not just the gRPC adapter. See contract.Error's doc comment for
+// the general policy this follows.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if e == nil || !ok || t == nil {
+		return false
+	}
+
+	return e.code == t.code
+}
+
 // ------ contract.Error getters (Go initialisms)
 
 func (e *Error) HTTPStatus() int         { return e.httpStatus }
@@ -372,6 +372,26 @@ func TestNew_WithCause_Unwrap(t *testing.T) {
 	}
 }
 
+func TestError_Is_MatchesByCodeNotPointer(t *testing.T) {
+	t.Parallel()
+
+	original := apiError.New(404, "customer.not_found", "not_found", "customer 42 not found", nil)
+	reconstructed := apiError.New(404, "customer.not_found", "not_found", "", nil)
+
+	if !errors.Is(reconstructed, original) {
+		t.Fatalf("errors.Is should match *Error values sharing a Code even across distinct pointers")
+	}
+
+	other := apiError.New(500, "internal.error", "internal", "internal error", nil)
+	if errors.Is(reconstructed, other) {
+		t.Fatalf("errors.Is must not match *Error values with different Codes")
+	}
+
+	if errors.Is(reconstructed, errors.New("not an *Error")) {
+		t.Fatalf("errors.Is must not match a non-*Error target")
+	}
+}
+
 func TestE_WithCauseOption(t *testing.T) {
 	t.Parallel()
 	cause := errors.New("sql: no rows in result set")
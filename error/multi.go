@@ -0,0 +1,204 @@
+package error
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/next-trace/scg-error/contract"
+)
+
+// MultiError aggregates zero or more errors into a single contract.Error.
+//
+// Its own HTTPStatus/Code/Key/Detail are taken from the "worst" child: the
+// highest HTTPStatus wins, ties broken by first occurrence. Children are
+// normalized to *Error via Ensure so Context() can describe each one
+// uniformly, and nested *MultiError values are flattened on insertion.
+type MultiError struct {
+	errs []*Error
+}
+
+// compile-time guarantee that *MultiError implements contract.Error.
+var _ contract.Error = (*MultiError)(nil)
+
+// ------ construction
+
+// Combine aggregates errs into a *MultiError, skipping nils and flattening
+// any nested *MultiError. Returns nil if no non-nil errors remain, matching
+// the zero-value-means-empty convention used elsewhere in this package.
+func Combine(errs ...error) *MultiError {
+	return Append(nil, errs...)
+}
+
+// Append adds errs onto dst (allocating a new *MultiError if dst is nil),
+// skipping nils and flattening any nested *MultiError, and returns dst.
+func Append(dst *MultiError, errs ...error) *MultiError {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if dst == nil {
+			dst = &MultiError{}
+		}
+
+		dst.add(err)
+	}
+
+	return dst
+}
+
+// Add appends err to m (allocating on first use if m is nil), skipping nil
+// and flattening a nested *MultiError, and returns the receiver so callers
+// can collect incrementally, e.g. during a validation run:
+//
+//	var errs *error.MultiError
+//	errs = errs.Add(validateName(in)).Add(validateEmail(in))
+func (m *MultiError) Add(err error) *MultiError {
+	if err == nil {
+		return m
+	}
+
+	if m == nil {
+		m = &MultiError{}
+	}
+
+	m.add(err)
+
+	return m
+}
+
+func (m *MultiError) add(err error) {
+	var nested *MultiError
+	if errors.As(err, &nested) {
+		m.errs = append(m.errs, nested.errs...)
+		return
+	}
+
+	m.errs = append(m.errs, Ensure(err))
+}
+
+// ------ standard error interface
+
+func (m *MultiError) Error() string {
+	if m == nil || len(m.errs) == 0 {
+		return "multi[0]: (worst=0)"
+	}
+
+	codes := make([]string, len(m.errs))
+	for i, e := range m.errs {
+		codes[i] = e.Code()
+	}
+
+	return fmt.Sprintf("multi[%d]: %s (worst=%d)", len(m.errs), strings.Join(codes, ","), m.top().HTTPStatus())
+}
+
+// Unwrap returns the worst child so the standard single-error Is/As chain
+// still reaches it. Is and As below additionally check every child, which is
+// what lets errors.Is/errors.As traverse the whole aggregate rather than
+// only the worst one.
+func (m *MultiError) Unwrap() error {
+	if t := m.top(); t != nil {
+		return t
+	}
+
+	return nil
+}
+
+// Is lets errors.Is(m, target) match against any child, not just the one
+// returned by Unwrap().
+func (m *MultiError) Is(target error) bool {
+	for _, e := range m.errs {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As lets errors.As(m, target) match against any child, not just the one
+// returned by Unwrap().
+func (m *MultiError) As(target any) bool {
+	for _, e := range m.errs {
+		if errors.As(e, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ------ contract.Error getters (Go initialisms)
+
+func (m *MultiError) HTTPStatus() int {
+	if t := m.top(); t != nil {
+		return t.HTTPStatus()
+	}
+
+	return 0
+}
+
+func (m *MultiError) Code() string {
+	if t := m.top(); t != nil {
+		return t.Code()
+	}
+
+	return ""
+}
+
+func (m *MultiError) Key() string {
+	if t := m.top(); t != nil {
+		return t.Key()
+	}
+
+	return ""
+}
+
+func (m *MultiError) Detail() string {
+	if t := m.top(); t != nil {
+		return t.Detail()
+	}
+
+	return ""
+}
+
+// Context returns {"errors": [...]}, one entry per child in insertion order,
+// each shaped as {code,key,http_status,detail,context}. Every nested context
+// is already defensively cloned by the child's own Context().
+func (m *MultiError) Context() map[string]any {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+
+	list := make([]map[string]any, len(m.errs))
+	for i, e := range m.errs {
+		list[i] = map[string]any{
+			"code":        e.Code(),
+			"key":         e.Key(),
+			"http_status": e.HTTPStatus(),
+			"detail":      e.Detail(),
+			"context":     e.Context(),
+		}
+	}
+
+	return map[string]any{"errors": list}
+}
+
+// top picks the deterministic worst child: highest HTTPStatus wins, ties
+// broken by first occurrence. Returns nil if m has no children.
+func (m *MultiError) top() *Error {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+
+	best := m.errs[0]
+
+	for _, e := range m.errs[1:] {
+		if e.HTTPStatus() > best.HTTPStatus() {
+			best = e
+		}
+	}
+
+	return best
+}
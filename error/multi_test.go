@@ -0,0 +1,179 @@
+package error_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/next-trace/scg-error/contract"
+	apiError "github.com/next-trace/scg-error/error"
+)
+
+func TestCombine_NilAndEmpty(t *testing.T) {
+	t.Parallel()
+
+	if got := apiError.Combine(); got != nil {
+		t.Fatalf("Combine() = %v; want nil", got)
+	}
+
+	if got := apiError.Combine(nil, nil); got != nil {
+		t.Fatalf("Combine(nil, nil) = %v; want nil", got)
+	}
+}
+
+func TestCombine_PicksWorstHTTPStatusWithFirstOccurrenceTiebreak(t *testing.T) {
+	t.Parallel()
+
+	e1 := apiError.New(http.StatusBadRequest, "a.bad", "validation", "bad a", nil)
+	e2 := apiError.New(http.StatusNotFound, "b.missing", "not_found", "missing b", nil)
+	e3 := apiError.New(http.StatusNotFound, "c.missing", "not_found", "missing c", nil)
+
+	m := apiError.Combine(e1, e2, e3)
+
+	if got, want := m.HTTPStatus(), http.StatusNotFound; got != want {
+		t.Fatalf("HTTPStatus=%d want=%d", got, want)
+	}
+
+	if got, want := m.Code(), "b.missing"; got != want {
+		t.Fatalf("Code=%q want=%q (first occurrence of the worst status)", got, want)
+	}
+}
+
+func TestCombine_SkipsNilsAndFlattensNested(t *testing.T) {
+	t.Parallel()
+
+	inner := apiError.Combine(
+		apiError.New(500, "x", "internal", "x", nil),
+		apiError.New(500, "y", "internal", "y", nil),
+	)
+
+	m := apiError.Combine(nil, inner, nil, apiError.New(400, "z", "validation", "z", nil))
+
+	if got, want := len(m.Context()["errors"].([]map[string]any)), 3; got != want {
+		t.Fatalf("flattened child count=%d want=%d", got, want)
+	}
+}
+
+func TestAppend_AllocatesOnNilDst(t *testing.T) {
+	t.Parallel()
+
+	var dst *apiError.MultiError
+
+	dst = apiError.Append(dst, apiError.New(500, "a", "internal", "a", nil))
+	dst = apiError.Append(dst, apiError.New(404, "b", "not_found", "b", nil))
+
+	if got, want := dst.HTTPStatus(), 500; got != want {
+		t.Fatalf("HTTPStatus=%d want=%d", got, want)
+	}
+}
+
+func TestMultiError_Add_Fluent(t *testing.T) {
+	t.Parallel()
+
+	var m *apiError.MultiError
+
+	m = m.Add(apiError.New(400, "a", "validation", "a", nil)).
+		Add(nil).
+		Add(apiError.New(422, "b", "validation", "b", nil))
+
+	if got, want := len(m.Context()["errors"].([]map[string]any)), 2; got != want {
+		t.Fatalf("child count=%d want=%d", got, want)
+	}
+}
+
+func TestMultiError_Context_Shape(t *testing.T) {
+	t.Parallel()
+
+	m := apiError.Combine(
+		apiError.New(http.StatusNotFound, "customer.not_found", "not_found", "customer 42 not found", map[string]any{"id": "42"}),
+	)
+
+	list, ok := m.Context()["errors"].([]map[string]any)
+	if !ok || len(list) != 1 {
+		t.Fatalf("unexpected context shape: %#v", m.Context())
+	}
+
+	entry := list[0]
+	if entry["code"] != "customer.not_found" || entry["key"] != "not_found" || entry["http_status"] != 404 {
+		t.Fatalf("unexpected entry: %#v", entry)
+	}
+
+	ctx, _ := entry["context"].(map[string]any)
+	if ctx["id"] != "42" {
+		t.Fatalf("unexpected nested context: %#v", ctx)
+	}
+}
+
+func TestMultiError_ErrorString_NoLeak(t *testing.T) {
+	t.Parallel()
+
+	m := apiError.Combine(
+		apiError.New(http.StatusInternalServerError, "internal.error", "internal", "do not leak this detail", map[string]any{"secret": "do-not-leak"}),
+	)
+
+	msg := m.Error()
+	if !contains(msg, "multi[1]") || !contains(msg, "internal.error") || !contains(msg, "worst=500") {
+		t.Fatalf("Error() missing expected parts: %q", msg)
+	}
+
+	if contains(msg, "secret") || contains(msg, "do-not-leak") || contains(msg, "do not leak this detail") {
+		t.Fatalf("Error() leaked detail/context: %q", msg)
+	}
+}
+
+func TestMultiError_IsAndAs_TraverseEveryChild(t *testing.T) {
+	t.Parallel()
+
+	rowNotFound := errors.New("row not found")
+	connRefused := errors.New("connection refused")
+
+	e1 := apiError.Wrap(rowNotFound, http.StatusNotFound, "a.not_found", "not_found", "a", nil)
+	e2 := apiError.Wrap(connRefused, http.StatusInternalServerError, "b.internal", "internal", "b", nil)
+
+	m := apiError.Combine(e1, e2)
+
+	if !errors.Is(m, rowNotFound) {
+		t.Fatalf("errors.Is(m, rowNotFound) = false; want true")
+	}
+
+	if !errors.Is(m, connRefused) {
+		t.Fatalf("errors.Is(m, connRefused) = false; want true")
+	}
+
+	var out *apiError.Error
+	if !errors.As(m, &out) {
+		t.Fatalf("errors.As(m, *Error) = false; want true")
+	}
+}
+
+func TestMultiError_SatisfiesContractError(t *testing.T) {
+	t.Parallel()
+
+	e1 := apiError.New(400, "a", "validation", "a", nil)
+	e2 := apiError.New(404, "b", "not_found", "b", nil)
+
+	m := apiError.Combine(e1, e2)
+
+	var ce contract.Error = m
+	if ce.HTTPStatus() != m.HTTPStatus() || ce.Code() != m.Code() {
+		t.Fatalf("*MultiError does not behave as contract.Error through the interface: %+v", ce)
+	}
+}
+
+func TestMultiError_NilReceiver(t *testing.T) {
+	t.Parallel()
+
+	var m *apiError.MultiError
+
+	if got := m.HTTPStatus(); got != 0 {
+		t.Fatalf("nil MultiError HTTPStatus()=%d want=0", got)
+	}
+
+	if got := m.Context(); got != nil {
+		t.Fatalf("nil MultiError Context()=%v want=nil", got)
+	}
+
+	if got := m.Error(); got != "multi[0]: (worst=0)" {
+		t.Fatalf("nil MultiError Error()=%q", got)
+	}
+}
@@ -21,8 +21,24 @@ func WithContext(ctx map[string]any) Option {
 // WithCause sets the underlying cause to be returned by Unwrap().
 func WithCause(cause error) Option { return func(e *Error) { e.cause = cause } }
 
+// WithStack opts an Error into call-site stack capture during E().
+// Use this when SetDefaultCaptureStack(false) (the default) but a specific
+// construction site still needs a trace, e.g. for a rare/unexpected path.
+// The resulting stack starts at the caller of E, not at WithStack itself.
+func WithStack() Option {
+	return func(e *Error) { e.stack = captureStack(stackCallersSkip + stackOptionIndirection) }
+}
+
+// WithStackSkip behaves like WithStack but additionally skips skip extra
+// caller frames, for helpers that construct errors on behalf of another
+// caller and want the trace to start further up the stack.
+func WithStackSkip(skip int) Option {
+	return func(e *Error) { e.stack = captureStack(stackCallersSkip + stackOptionIndirection + skip) }
+}
+
 // E is a minimal builder when you don’t want the full New(...) signature.
-// Defaults: HTTPStatus=500, Detail="error".
+// Defaults: HTTPStatus=500, Detail="error". Stack capture is off unless
+// WithStack/WithStackSkip is passed or SetDefaultCaptureStack(true) is set.
 func E(code, key string, opts ...Option) *Error {
 	e := &Error{
 		httpStatus: defaultHTTPStatus,
@@ -30,6 +46,11 @@ func E(code, key string, opts ...Option) *Error {
 		key:        key,
 		detail:     "error",
 	}
+
+	if defaultCaptureStack() {
+		e.stack = captureStack(stackCallersSkip)
+	}
+
 	for _, o := range opts {
 		o(e)
 	}
@@ -0,0 +1,95 @@
+package error
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/next-trace/scg-error/contract"
+)
+
+// Frame is an alias of contract.Frame for convenience within this package.
+type Frame = contract.Frame
+
+// compile-time guarantee that *Error satisfies the optional stack interface.
+var _ contract.StackTracer = (*Error)(nil)
+
+// maxStackDepth bounds how many PCs are recorded per capture.
+const maxStackDepth = 32
+
+// stackCallersSkip hides runtime.Callers, captureStack and the function that
+// calls captureStack directly (E, Wrap, WrapWithStack), so the first frame
+// of a captured stack is always the caller of this package.
+const stackCallersSkip = 3
+
+// stackOptionIndirection accounts for the extra Option closure frame that
+// WithStack/WithStackSkip introduce between captureStack and E.
+const stackOptionIndirection = 1
+
+// captureStackDefault is the process-wide toggle set via SetDefaultCaptureStack.
+var captureStackDefault atomic.Bool
+
+// SetDefaultCaptureStack enables or disables stack capture for every
+// subsequent E/Wrap/Ensure call. WithStack, WithStackSkip and WrapWithStack
+// capture regardless of it; there is no per-call opt-out. Services
+// typically enable this in development and leave it disabled in production
+// to avoid the runtime.Callers cost on hot error paths.
+func SetDefaultCaptureStack(enabled bool) { captureStackDefault.Store(enabled) }
+
+// defaultCaptureStack reports the current process-wide toggle.
+func defaultCaptureStack() bool { return captureStackDefault.Load() }
+
+// captureStack records up to maxStackDepth raw PCs, skipping skip frames.
+func captureStack(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip, pcs)
+
+	return pcs[:n]
+}
+
+// Stack resolves the captured PCs into Frame values, or returns nil if no
+// stack was captured for this error. Resolution is deferred to this call so
+// construction stays cheap even when a trace was recorded.
+func (e *Error) Stack() []contract.Frame {
+	if e == nil || len(e.stack) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(e.stack)
+	out := make([]contract.Frame, 0, len(e.stack))
+
+	for {
+		f, more := frames.Next()
+		out = append(out, contract.Frame{Function: f.Function, File: f.File, Line: f.Line})
+
+		if !more {
+			break
+		}
+	}
+
+	return out
+}
+
+// StackString renders Stack() as a multi-line, human-readable trace for logs.
+// It returns "" when no stack was captured. The Error() string never
+// includes this; callers must opt in explicitly.
+func (e *Error) StackString() string {
+	frames := e.Stack()
+	if len(frames) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	for _, f := range frames {
+		b.WriteString(f.Function)
+		b.WriteString("\n\t")
+		b.WriteString(f.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(f.Line))
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package error_test
+
+import (
+	"errors"
+	"testing"
+
+	apiError "github.com/next-trace/scg-error/error"
+)
+
+func TestWithStack_CapturesCallerNotE(t *testing.T) {
+	t.Parallel()
+
+	e := apiError.E("c", "k", apiError.WithStack())
+	frames := e.Stack()
+
+	if len(frames) == 0 {
+		t.Fatalf("expected captured frames, got none")
+	}
+
+	if frames[0].Function == "" {
+		t.Fatalf("expected top frame to have a function name")
+	}
+
+	for _, f := range frames {
+		if f.Function == "github.com/next-trace/scg-error/error.E" {
+			t.Fatalf("Stack() leaked E's own frame: %+v", frames)
+		}
+	}
+}
+
+// wrapHelperWithSkip stands in for a helper that constructs an Error on
+// behalf of its own caller and wants the trace to start there, not inside
+// itself.
+func wrapHelperWithSkip() *apiError.Error {
+	return apiError.E("c", "k", apiError.WithStackSkip(1))
+}
+
+func TestWithStackSkip_MovesTopFrameUpByN(t *testing.T) {
+	t.Parallel()
+
+	e := wrapHelperWithSkip()
+	frames := e.Stack()
+
+	if len(frames) == 0 {
+		t.Fatalf("expected captured frames, got none")
+	}
+
+	for _, f := range frames {
+		if f.Function == "github.com/next-trace/scg-error/error_test.wrapHelperWithSkip" {
+			t.Fatalf("WithStackSkip(1) did not hide the helper's own frame: %+v", frames)
+		}
+	}
+
+	if !contains(frames[0].Function, "TestWithStackSkip_MovesTopFrameUpByN") {
+		t.Fatalf("expected top frame to be this test (the helper's caller), got %+v", frames[0])
+	}
+}
+
+func TestEnsure_CapturesCallerNotEnsure(t *testing.T) {
+	apiError.SetDefaultCaptureStack(true)
+	defer apiError.SetDefaultCaptureStack(false)
+
+	e := apiError.Ensure(errors.New("boom"))
+	frames := e.Stack()
+
+	if len(frames) == 0 {
+		t.Fatalf("expected captured frames, got none")
+	}
+
+	for _, f := range frames {
+		if f.Function == "github.com/next-trace/scg-error/error.Ensure" {
+			t.Fatalf("Stack() leaked Ensure's own frame: %+v", frames)
+		}
+	}
+
+	if !contains(frames[0].Function, "TestEnsure_CapturesCallerNotEnsure") {
+		t.Fatalf("expected top frame to be this test (Ensure's caller), got %+v", frames[0])
+	}
+}
+
+func TestE_NoStackByDefault(t *testing.T) {
+	t.Parallel()
+
+	e := apiError.E("c", "k")
+	if got := e.Stack(); got != nil {
+		t.Fatalf("expected nil Stack() without WithStack/default toggle, got %v", got)
+	}
+
+	if got := e.StackString(); got != "" {
+		t.Fatalf("expected empty StackString() without capture, got %q", got)
+	}
+}
+
+func TestSetDefaultCaptureStack(t *testing.T) {
+	apiError.SetDefaultCaptureStack(true)
+	defer apiError.SetDefaultCaptureStack(false)
+
+	e := apiError.E("c", "k")
+	if len(e.Stack()) == 0 {
+		t.Fatalf("expected default capture to populate Stack()")
+	}
+
+	wrapped := apiError.Wrap(errors.New("boom"), 500, "c", "k", "d", nil)
+	if len(wrapped.Stack()) == 0 {
+		t.Fatalf("expected default capture to populate Wrap's Stack()")
+	}
+}
+
+func TestWrapWithStack_ForcesCapture(t *testing.T) {
+	t.Parallel()
+
+	e := apiError.WrapWithStack(errors.New("boom"), 500, "c", "k", "d", nil)
+	if len(e.Stack()) == 0 {
+		t.Fatalf("expected WrapWithStack to always capture a stack")
+	}
+}
+
+func TestError_StringExcludesStack(t *testing.T) {
+	t.Parallel()
+
+	e := apiError.E("c", "k", apiError.WithStack())
+	if msg := e.Error(); contains(msg, ".go:") {
+		t.Fatalf("Error() must not include stack info: %q", msg)
+	}
+}
+
+func BenchmarkE_NoStack(b *testing.B) {
+	apiError.SetDefaultCaptureStack(false)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = apiError.E("bench.code", "bench")
+	}
+}
+
+func BenchmarkE_WithStack(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = apiError.E("bench.code", "bench", apiError.WithStack())
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 // Wrap attaches a cause to a new Error. If cause is nil, an opaque cause is created.
 // It preserves the original cause for errors.Is / errors.As via Unwrap().
+// A stack is captured only if SetDefaultCaptureStack(true) is in effect; use
+// WrapWithStack to force capture regardless of the default.
 func Wrap(cause error, httpStatus int, code, key, detail string, ctx map[string]any) *Error {
 	if cause == nil {
 		cause = errors.New("unknown")
@@ -14,6 +16,19 @@ func Wrap(cause error, httpStatus int, code, key, detail string, ctx map[string]
 	e := New(httpStatus, code, key, detail, ctx)
 	e.cause = cause
 
+	if defaultCaptureStack() {
+		e.stack = captureStack(stackCallersSkip)
+	}
+
+	return e
+}
+
+// WrapWithStack behaves like Wrap but unconditionally captures a call-site
+// stack trace, regardless of the SetDefaultCaptureStack toggle.
+func WrapWithStack(cause error, httpStatus int, code, key, detail string, ctx map[string]any) *Error {
+	e := Wrap(cause, httpStatus, code, key, detail, ctx)
+	e.stack = captureStack(stackCallersSkip)
+
 	return e
 }
 
@@ -34,5 +49,14 @@ func Ensure(err error) *Error {
 		return e
 	}
 
-	return Wrap(err, defaultHTTPStatus, "internal.error", "internal", "internal error", nil)
+	wrapped := New(defaultHTTPStatus, "internal.error", "internal", "internal error", nil, err)
+
+	// Built directly via New rather than Wrap: Wrap's own capture assumes
+	// it is called directly, and the extra Ensure frame on the stack would
+	// otherwise end up as the reported top frame.
+	if defaultCaptureStack() {
+		wrapped.stack = captureStack(stackCallersSkip)
+	}
+
+	return wrapped
 }
@@ -0,0 +1,275 @@
+// Package grpcstatus converts between contract.Error and gRPC's
+// google.golang.org/grpc/status, so services can expose the same errors
+// over gRPC that they already expose over HTTP.
+package grpcstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/next-trace/scg-error/catalog"
+	"github.com/next-trace/scg-error/contract"
+	apierror "github.com/next-trace/scg-error/error"
+)
+
+// domain is the ErrorInfo.Domain attached to the primary converted status
+// detail, identifying this module as the source of the Reason/Metadata
+// convention.
+const domain = "scg-error"
+
+// causeDomain marks the second ErrorInfo detail ToStatus attaches when e
+// wraps an *error.Error cause, so FromStatus can tell it apart from the
+// primary detail and reconstruct the cause via WithCause.
+const causeDomain = domain + ".cause"
+
+// codeMapping is the active HTTPStatus -> codes.Code mapper, overridable
+// via SetCodeMapping.
+var codeMapping atomic.Value // func(int) codes.Code
+
+func init() {
+	codeMapping.Store(defaultCodeMapping)
+}
+
+// SetCodeMapping overrides the HTTPStatus -> codes.Code mapping used by
+// ToStatus. Pass nil to restore the default mapping.
+func SetCodeMapping(mapping func(httpStatus int) codes.Code) {
+	if mapping == nil {
+		mapping = defaultCodeMapping
+	}
+
+	codeMapping.Store(mapping)
+}
+
+func currentCodeMapping() func(int) codes.Code {
+	return codeMapping.Load().(func(int) codes.Code)
+}
+
+// defaultCodeMapping follows the conventional HTTP-to-gRPC mapping used by
+// most Google APIs.
+func defaultCodeMapping(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	}
+
+	switch {
+	case httpStatus >= 200 && httpStatus < 300:
+		return codes.OK
+	case httpStatus >= 500:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// reverseMapping is used by FromStatus to recover an approximate HTTPStatus
+// from a codes.Code; it is the inverse of defaultCodeMapping and is not
+// affected by SetCodeMapping, since an override is HTTP->gRPC only.
+var reverseMapping = map[codes.Code]int{
+	codes.OK:                http.StatusOK,
+	codes.InvalidArgument:   http.StatusBadRequest,
+	codes.Unauthenticated:   http.StatusUnauthorized,
+	codes.PermissionDenied:  http.StatusForbidden,
+	codes.NotFound:          http.StatusNotFound,
+	codes.Aborted:           http.StatusConflict,
+	codes.ResourceExhausted: http.StatusTooManyRequests,
+	codes.Unavailable:       http.StatusServiceUnavailable,
+	codes.Internal:          http.StatusInternalServerError,
+	codes.Unknown:           http.StatusInternalServerError,
+}
+
+// reservedMetadataKeys guards ErrorInfo.Metadata against a context entry
+// clobbering the key we use to carry contract.Error's Key.
+var reservedMetadataKeys = map[string]bool{"key": true}
+
+// ToStatus converts e into a *status.Status whose code comes from the
+// active code mapping, whose message is e.Detail(), and which carries an
+// ErrorInfo detail with Code (as Reason), Key, and a defensively-cloned
+// Context() (as Metadata, stringified since ErrorInfo.Metadata is
+// map[string]string). If e wraps an *error.Error cause, a second ErrorInfo
+// detail carries the cause's Code/Key/HTTPStatus so FromStatus can
+// reconstruct it and preserve errors.Is against it.
+func ToStatus(e contract.Error) *status.Status {
+	return buildStatus(currentCodeMapping()(e.HTTPStatus()), e)
+}
+
+// ToStatusFromCatalog behaves like ToStatus, except the gRPC code comes from
+// the catalog.Spec registered under code when that Spec sets GRPCCode,
+// overriding the active code mapping's HTTPStatus-derived choice. Falls back
+// to ToStatus's mapping when code isn't registered or its Spec leaves
+// GRPCCode nil.
+func ToStatusFromCatalog(code string, e contract.Error) *status.Status {
+	grpcCode := currentCodeMapping()(e.HTTPStatus())
+
+	if spec, ok := catalog.Lookup(code); ok && spec.GRPCCode != nil {
+		grpcCode = codes.Code(*spec.GRPCCode)
+	}
+
+	return buildStatus(grpcCode, e)
+}
+
+func buildStatus(grpcCode codes.Code, e contract.Error) *status.Status {
+	st := status.New(grpcCode, e.Detail())
+	st = attachDetail(st, primaryErrorInfo(e))
+
+	if cause, ok := causeAsAPIError(e); ok {
+		st = attachDetail(st, causeErrorInfo(cause))
+	}
+
+	return st
+}
+
+func attachDetail(st *status.Status, info *errdetails.ErrorInfo) *status.Status {
+	withDetail, err := st.WithDetails(info)
+	if err != nil {
+		return st
+	}
+
+	return withDetail
+}
+
+func primaryErrorInfo(e contract.Error) *errdetails.ErrorInfo {
+	metadata := make(map[string]string)
+
+	for k, v := range e.Context() {
+		if reservedMetadataKeys[k] {
+			continue
+		}
+
+		metadata[k] = toMetadataString(v)
+	}
+
+	metadata["key"] = e.Key()
+
+	return &errdetails.ErrorInfo{Reason: e.Code(), Domain: domain, Metadata: metadata}
+}
+
+func causeErrorInfo(cause *apierror.Error) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{
+		Reason: cause.Code(),
+		Domain: causeDomain,
+		Metadata: map[string]string{
+			"key":         cause.Key(),
+			"http_status": strconv.Itoa(cause.HTTPStatus()),
+		},
+	}
+}
+
+// causeAsAPIError reports whether e's cause chain contains an *error.Error,
+// i.e. the part of it that ToStatus can actually carry across the wire.
+func causeAsAPIError(e contract.Error) (*apierror.Error, bool) {
+	var out *apierror.Error
+	if errors.As(e.Unwrap(), &out) {
+		return out, true
+	}
+
+	return nil, false
+}
+
+// FromStatus converts s back into an *error.Error, recovering Code/Key/
+// Context from its primary ErrorInfo detail when present, and reattaching
+// a cause (via WithCause) when a secondary cause ErrorInfo detail is
+// present. HTTPStatus is recovered approximately from s.Code() via
+// reverseMapping. Returns nil for a nil s.
+func FromStatus(s *status.Status) *apierror.Error {
+	if s == nil {
+		return nil
+	}
+
+	httpStatus, ok := reverseMapping[s.Code()]
+	if !ok {
+		httpStatus = http.StatusInternalServerError
+	}
+
+	code, key := s.Code().String(), ""
+	ctx := map[string]any{}
+
+	var cause *apierror.Error
+
+	for _, d := range s.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+
+		if info.GetDomain() == causeDomain {
+			cause = causeFromErrorInfo(info)
+			continue
+		}
+
+		code = info.GetReason()
+
+		for k, v := range info.GetMetadata() {
+			if k == "key" {
+				key = v
+				continue
+			}
+
+			ctx[k] = v
+		}
+	}
+
+	if cause != nil {
+		return apierror.New(httpStatus, code, key, s.Message(), ctx, cause)
+	}
+
+	return apierror.New(httpStatus, code, key, s.Message(), ctx)
+}
+
+// causeFromErrorInfo rebuilds the cause *error.Error from a cause-domain
+// ErrorInfo detail. Only Code/Key/HTTPStatus survive the round trip; Detail
+// is intentionally left empty since it was never transmitted.
+func causeFromErrorInfo(info *errdetails.ErrorInfo) *apierror.Error {
+	meta := info.GetMetadata()
+	httpStatus, _ := strconv.Atoi(meta["http_status"])
+
+	return apierror.New(httpStatus, info.GetReason(), meta["key"], "", nil)
+}
+
+func toMetadataString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	return fmt.Sprint(v)
+}
+
+// UnaryServerInterceptor converts any error returned by a unary handler
+// into a gRPC status via Ensure + ToStatus, so handlers can keep returning
+// contract.Error values (or plain errors) without converting them by hand.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, ToStatus(apierror.Ensure(err)).Err()
+		}
+
+		return resp, nil
+	}
+}
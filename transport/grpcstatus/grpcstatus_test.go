@@ -0,0 +1,195 @@
+package grpcstatus_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/next-trace/scg-error/catalog"
+	apierror "github.com/next-trace/scg-error/error"
+	"github.com/next-trace/scg-error/transport/grpcstatus"
+)
+
+func TestToStatus_MapsHTTPStatusAndCarriesMetadata(t *testing.T) {
+	t.Parallel()
+
+	e := apierror.New(http.StatusNotFound, "customer.not_found", "not_found", "customer 42 not found",
+		map[string]any{"customer_id": "42"})
+
+	st := grpcstatus.ToStatus(e)
+
+	if st.Code() != codes.NotFound {
+		t.Fatalf("code=%v want=%v", st.Code(), codes.NotFound)
+	}
+
+	if st.Message() != "customer 42 not found" {
+		t.Fatalf("message=%q", st.Message())
+	}
+
+	var info *errdetails.ErrorInfo
+
+	for _, d := range st.Details() {
+		if ei, ok := d.(*errdetails.ErrorInfo); ok {
+			info = ei
+			break
+		}
+	}
+
+	if info == nil || info.GetReason() != "customer.not_found" ||
+		info.GetMetadata()["key"] != "not_found" || info.GetMetadata()["customer_id"] != "42" {
+		t.Fatalf("unexpected ErrorInfo detail: %+v", info)
+	}
+}
+
+func TestFromStatus_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := apierror.New(http.StatusNotFound, "customer.not_found", "not_found", "customer 42 not found",
+		map[string]any{"customer_id": "42"})
+
+	decoded := grpcstatus.FromStatus(grpcstatus.ToStatus(original))
+
+	if decoded.HTTPStatus() != original.HTTPStatus() {
+		t.Fatalf("HTTPStatus=%d want=%d", decoded.HTTPStatus(), original.HTTPStatus())
+	}
+
+	if decoded.Code() != original.Code() || decoded.Key() != original.Key() || decoded.Detail() != original.Detail() {
+		t.Fatalf("round-trip mismatch: got=%+v", decoded)
+	}
+
+	if decoded.Context()["customer_id"] != "42" {
+		t.Fatalf("round-trip lost context: %#v", decoded.Context())
+	}
+}
+
+func TestFromStatus_Nil(t *testing.T) {
+	t.Parallel()
+
+	if got := grpcstatus.FromStatus(nil); got != nil {
+		t.Fatalf("FromStatus(nil) = %v; want nil", got)
+	}
+}
+
+func TestSetCodeMapping_Override(t *testing.T) {
+	grpcstatus.SetCodeMapping(func(httpStatus int) codes.Code {
+		if httpStatus == http.StatusTeapot {
+			return codes.FailedPrecondition
+		}
+
+		return codes.Unknown
+	})
+	defer grpcstatus.SetCodeMapping(nil)
+
+	e := apierror.New(http.StatusTeapot, "teapot", "teapot", "I'm a teapot", nil)
+	if got := grpcstatus.ToStatus(e).Code(); got != codes.FailedPrecondition {
+		t.Fatalf("code=%v want=%v", got, codes.FailedPrecondition)
+	}
+}
+
+func TestToStatusFromCatalog_UsesSpecGRPCCodeOverride(t *testing.T) {
+	t.Parallel()
+
+	override := int(codes.FailedPrecondition)
+	catalog.Register("grpcstatus_test.overridden", catalog.Spec{
+		Key:        "conflict",
+		HTTPStatus: http.StatusConflict,
+		Detail:     "conflicting state",
+		GRPCCode:   &override,
+	})
+
+	e := catalog.New("grpcstatus_test.overridden")
+
+	st := grpcstatus.ToStatusFromCatalog("grpcstatus_test.overridden", e)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("code=%v want=%v (catalog override should win over the HTTPStatus mapping)", st.Code(), codes.FailedPrecondition)
+	}
+}
+
+func TestToStatusFromCatalog_FallsBackWhenNoOverride(t *testing.T) {
+	t.Parallel()
+
+	catalog.Register("grpcstatus_test.no_override", catalog.Spec{
+		Key:        "not_found",
+		HTTPStatus: http.StatusNotFound,
+		Detail:     "missing",
+	})
+
+	e := catalog.New("grpcstatus_test.no_override")
+
+	st := grpcstatus.ToStatusFromCatalog("grpcstatus_test.no_override", e)
+	if st.Code() != codes.NotFound {
+		t.Fatalf("code=%v want=%v (should fall back to the HTTPStatus mapping)", st.Code(), codes.NotFound)
+	}
+}
+
+func TestToStatusFromCatalog_UnregisteredCodeFallsBack(t *testing.T) {
+	t.Parallel()
+
+	e := apierror.New(http.StatusNotFound, "grpcstatus_test.unregistered", "not_found", "missing", nil)
+
+	st := grpcstatus.ToStatusFromCatalog("grpcstatus_test.does_not_exist", e)
+	if st.Code() != codes.NotFound {
+		t.Fatalf("code=%v want=%v (unregistered catalog code should fall back to the HTTPStatus mapping)", st.Code(), codes.NotFound)
+	}
+}
+
+func TestUnaryServerInterceptor_ConvertsErrors(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("boom")
+	interceptor := grpcstatus.UnaryServerInterceptor()
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(_ context.Context, _ any) (any, error) {
+		return nil, cause
+	})
+
+	if err == nil {
+		t.Fatalf("expected interceptor to return a converted error")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("expected a *status.Status error, got %v", err)
+	}
+
+	decoded := grpcstatus.FromStatus(st)
+	if decoded.Code() != "internal.error" {
+		t.Fatalf("expected Ensure's internal.error code, got %q", decoded.Code())
+	}
+}
+
+func TestRoundTrip_ErrorsIsAgainstOriginalCause(t *testing.T) {
+	t.Parallel()
+
+	cause := apierror.New(http.StatusNotFound, "row.not_found", "not_found", "row 7 not found", nil)
+	original := apierror.Wrap(cause, http.StatusInternalServerError, "repository.failure", "internal", "repository failure", nil)
+
+	decoded := grpcstatus.FromStatus(grpcstatus.ToStatus(original))
+
+	if decoded.Code() != original.Code() || decoded.Key() != original.Key() {
+		t.Fatalf("decoded top-level fields mismatch: got=%+v", decoded)
+	}
+
+	// The cause's Code/Key/HTTPStatus must survive the round trip well
+	// enough that errors.Is(decoded, cause) succeeds, even though decoded's
+	// cause is a distinct *Error reconstructed from the wire, not the same
+	// pointer as cause.
+	if !errors.Is(decoded, cause) {
+		t.Fatalf("errors.Is(decoded, cause) = false; want true")
+	}
+
+	decodedCause, ok := errors.Unwrap(decoded).(*apierror.Error)
+	if !ok || decodedCause == cause {
+		t.Fatalf("expected decoded to unwrap to a reconstructed cause distinct from the original pointer")
+	}
+
+	if decodedCause.HTTPStatus() != cause.HTTPStatus() || decodedCause.Key() != cause.Key() {
+		t.Fatalf("reconstructed cause fields mismatch: got=%+v want=%+v", decodedCause, cause)
+	}
+}